@@ -0,0 +1,65 @@
+// Copyright 2020 Luca Stasio. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"strings"
+	"unicode"
+)
+
+// autoEnvName synthesizes an env var name from a field's struct path
+// (e.g. []string{"Service", "DB", "MaxConns"}) according to style.
+func autoEnvName(path []string, style AutoEnvStyle) string {
+	switch style {
+	case SnakeLower:
+		return strings.ToLower(strings.Join(path, "_"))
+	case ScreamingSnake:
+		return strings.ToUpper(strings.Join(splitPathWords(path), "_"))
+	case Kebab:
+		return strings.ToLower(strings.Join(splitPathWords(path), "-"))
+	case SnakeUpper:
+		fallthrough
+	default:
+		return strings.ToUpper(strings.Join(path, "_"))
+	}
+}
+
+// splitPathWords splits every camelCase/PascalCase segment of path
+// into its component words, e.g. []string{"DB", "MaxConns"} becomes
+// []string{"DB", "Max", "Conns"}.
+func splitPathWords(path []string) []string {
+	words := make([]string, 0, len(path))
+	for _, segment := range path {
+		words = append(words, splitWords(segment)...)
+	}
+	return words
+}
+
+// splitWords splits a camelCase/PascalCase identifier into its
+// component words, keeping runs of uppercase letters (acronyms like
+// "DB" or "HTTP") together.
+func splitWords(s string) []string {
+	runes := []rune(s)
+	var words []string
+	var cur []rune
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				words = append(words, string(cur))
+				cur = nil
+			}
+		}
+		cur = append(cur, r)
+	}
+
+	if len(cur) > 0 {
+		words = append(words, string(cur))
+	}
+
+	return words
+}
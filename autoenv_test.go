@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestWithAutoEnv(t *testing.T) {
+	type DBConfig struct {
+		MaxConns int
+	}
+	type ServiceConfig struct {
+		DB       DBConfig
+		Name     string
+		Internal string `env:"-"`
+	}
+	type Cfg struct {
+		Service ServiceConfig
+	}
+
+	os.Setenv("SERVICE_DB_MAX_CONNS", "7")
+	os.Setenv("SERVICE_NAME", "auth")
+	os.Setenv("INTERNAL", "leaked")
+
+	c := NewConfigurator().WithAutoEnv(ScreamingSnake)
+	cfg := Cfg{}
+	if err := c.readEnvVars(&cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if cfg.Service.DB.MaxConns != 7 {
+		t.Fatalf("cfg.Service.DB.MaxConns expected 7 is %d", cfg.Service.DB.MaxConns)
+	}
+
+	if cfg.Service.Name != "auth" {
+		t.Fatalf("cfg.Service.Name expected 'auth' is %q", cfg.Service.Name)
+	}
+
+	if cfg.Service.Internal != "" {
+		t.Fatalf("cfg.Service.Internal expected to stay empty (env:\"-\") is %q", cfg.Service.Internal)
+	}
+}
+
+func TestWithAutoEnvPrefixTag(t *testing.T) {
+	type DBConfig struct {
+		Host string
+	}
+	type Cfg struct {
+		DB DBConfig `env-prefix:"DATABASE"`
+	}
+
+	os.Setenv("DATABASE_HOST", "db.internal")
+
+	c := NewConfigurator().WithAutoEnv(ScreamingSnake)
+	cfg := Cfg{}
+	if err := c.readEnvVars(&cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if cfg.DB.Host != "db.internal" {
+		t.Fatalf("cfg.DB.Host expected 'db.internal' is %q", cfg.DB.Host)
+	}
+}
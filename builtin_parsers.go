@@ -0,0 +1,78 @@
+// Copyright 2020 Luca Stasio. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+// Base64 is a []byte bound to the built-in base64 parser below. Plain
+// []byte fields keep resolving to the raw bytes of the value, as
+// before; opt into base64 decoding by declaring a field as Base64
+// instead of []byte.
+type Base64 []byte
+
+// builtinParsers holds the custom type parsers every new Configurator
+// starts with (see RegisterParser). Build-tag gated files such as
+// builtin_parsers_uuid.go add more of these through
+// registerBuiltinParser, so a Configurator only pays for a dependency
+// when the matching tag is enabled.
+var builtinParsers = map[reflect.Type]func(string) (interface{}, error){
+	reflect.TypeOf(url.URL{}):       parseURL,
+	reflect.TypeOf(net.IP{}):        parseIP,
+	reflect.TypeOf(time.Location{}): parseLocation,
+	reflect.TypeOf(Base64(nil)):     parseBase64,
+	reflect.TypeOf(regexp.Regexp{}): parseRegexp,
+}
+
+func registerBuiltinParser(t reflect.Type, fn func(string) (interface{}, error)) {
+	builtinParsers[t] = fn
+}
+
+func parseURL(s string) (interface{}, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return *u, nil
+}
+
+func parseIP(s string) (interface{}, error) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", s)
+	}
+	return ip, nil
+}
+
+func parseLocation(s string) (interface{}, error) {
+	loc, err := time.LoadLocation(s)
+	if err != nil {
+		return nil, err
+	}
+	return *loc, nil
+}
+
+func parseBase64(s string) (interface{}, error) {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return Base64(decoded), nil
+}
+
+func parseRegexp(s string) (interface{}, error) {
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, err
+	}
+	return *re, nil
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestBuiltinParsers(t *testing.T) {
+	type Cfg struct {
+		Endpoint url.URL       `env:"EP" env-default:"https://example.com/path"`
+		Host     net.IP        `env:"HOST" env-default:"127.0.0.1"`
+		Pattern  regexp.Regexp `env:"PATTERN" env-default:"^abc$"`
+	}
+
+	c := NewConfigurator()
+	cfg := Cfg{}
+	if err := c.readEnvVars(&cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if cfg.Endpoint.Host != "example.com" {
+		t.Fatalf("cfg.Endpoint.Host expected 'example.com' is %q", cfg.Endpoint.Host)
+	}
+
+	if cfg.Host.String() != "127.0.0.1" {
+		t.Fatalf("cfg.Host expected '127.0.0.1' is %q", cfg.Host.String())
+	}
+
+	if cfg.Pattern.String() != "^abc$" {
+		t.Fatalf("cfg.Pattern expected '^abc$' is %q", cfg.Pattern.String())
+	}
+}
+
+func TestPlainByteSliceStaysRaw(t *testing.T) {
+	type Cfg struct {
+		Raw []byte `env-default:"hello world"`
+	}
+
+	c := NewConfigurator()
+	cfg := Cfg{}
+	if err := c.readEnvVars(&cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if string(cfg.Raw) != "hello world" {
+		t.Fatalf("cfg.Raw expected 'hello world' is %q", string(cfg.Raw))
+	}
+}
+
+func TestBase64FieldDecodes(t *testing.T) {
+	type Cfg struct {
+		Token Base64 `env-default:"aGVsbG8="`
+	}
+
+	c := NewConfigurator()
+	cfg := Cfg{}
+	if err := c.readEnvVars(&cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if string(cfg.Token) != "hello" {
+		t.Fatalf("cfg.Token expected 'hello' is %q", string(cfg.Token))
+	}
+}
+
+func TestRegisterParserOverride(t *testing.T) {
+	type Cfg struct {
+		ID string `env:"ID" env-default:"42"`
+	}
+
+	called := false
+	c := NewConfigurator().RegisterParser(reflect.TypeOf(""), func(s string) (interface{}, error) {
+		called = true
+		return "custom-" + s, nil
+	})
+
+	cfg := Cfg{}
+	if err := c.readEnvVars(&cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if !called {
+		t.Fatalf("expected the custom parser to be invoked")
+	}
+
+	if cfg.ID != "custom-42" {
+		t.Fatalf("cfg.ID expected 'custom-42' is %q", cfg.ID)
+	}
+}
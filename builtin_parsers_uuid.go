@@ -0,0 +1,20 @@
+// Copyright 2020 Luca Stasio. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build uuid
+// +build uuid
+
+package config
+
+import (
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+func init() {
+	registerBuiltinParser(reflect.TypeOf(uuid.UUID{}), func(s string) (interface{}, error) {
+		return uuid.Parse(s)
+	})
+}
@@ -12,6 +12,7 @@
 package config
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"io"
@@ -19,6 +20,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -44,6 +46,33 @@ const (
 	TagEnvDescription = "env-description"
 	// TagEnvRequired is the Flag to mark a field as required
 	TagEnvRequired = "env-required"
+	// TagEnvPrefix overrides the struct-path prefix WithAutoEnv uses
+	// to synthesize env names for a nested struct's fields.
+	TagEnvPrefix = "env-prefix"
+	// TagEnvFile is a fixed file path to read the field's value from,
+	// e.g. for mounted Docker/Kubernetes secrets.
+	TagEnvFile = "env-file"
+)
+
+// AutoEnvStyle selects how WithAutoEnv synthesizes an environment
+// variable name from a field's struct path when no explicit env tag
+// is present.
+type AutoEnvStyle int
+
+const (
+	// SnakeUpper joins struct path segments with "_" and upper-cases
+	// them without splitting camelCase field names, e.g.
+	// Service.MaxConns becomes SERVICE_MAXCONNS.
+	SnakeUpper AutoEnvStyle = iota + 1
+	// SnakeLower is SnakeUpper's lower-case counterpart, e.g.
+	// Service.MaxConns becomes service_maxconns.
+	SnakeLower
+	// ScreamingSnake additionally splits camelCase field names into
+	// words, e.g. Service.DB.MaxConns becomes SERVICE_DB_MAX_CONNS.
+	ScreamingSnake
+	// Kebab splits camelCase field names into lower-case, "-"-joined
+	// words, e.g. Service.DB.MaxConns becomes service-db-max-conns.
+	Kebab
 )
 
 // Setter is an interface for a custom value setter.
@@ -65,12 +94,21 @@ type Setter interface {
 
 // Configurator is the main struct to access configuration functionalities.
 type Configurator struct {
-	env            string
-	envPrefix      string
-	parser         Parser
-	paths          []string
-	fileNamePrefix string
-	fileType       string
+	env               string
+	envPrefix         string
+	parser            Parser
+	paths             []string
+	fileNamePrefix    string
+	fileType          string
+	interpolationVars map[string]string
+	strict            bool
+	current           atomic.Value
+	sources           []Source
+	customParsers     map[reflect.Type]func(string) (interface{}, error)
+	autoEnvStyle      AutoEnvStyle
+	envFilePrefix     string
+	loadedPath        string
+	loadedViaSource   bool
 }
 
 // NewConfigurator returns a new Configurator instance.
@@ -82,12 +120,18 @@ func NewConfigurator() *Configurator {
 	}
 	fileNamePrefix := defaultFileNamePrefix + "-" + env
 
+	customParsers := make(map[reflect.Type]func(string) (interface{}, error), len(builtinParsers))
+	for t, fn := range builtinParsers {
+		customParsers[t] = fn
+	}
+
 	return &Configurator{
 		env:            env,
 		envPrefix:      "",
 		paths:          defaultPaths,
 		fileNamePrefix: fileNamePrefix,
 		fileType:       defaultFileType,
+		customParsers:  customParsers,
 	}
 }
 
@@ -133,6 +177,56 @@ func (c *Configurator) WithParser(parser Parser) *Configurator {
 	return c
 }
 
+// RegisterParser registers a custom parsing function for t, consulted
+// in parseValue before the built-in Kind-based switch. This lets a
+// field bind to a rich type straight from its env/default tag without
+// implementing Setter.
+func (c *Configurator) RegisterParser(t reflect.Type, fn func(string) (interface{}, error)) *Configurator {
+	c.customParsers[t] = fn
+	return c
+}
+
+// WithParsers registers a batch of custom type parsers; see RegisterParser.
+func (c *Configurator) WithParsers(parsers map[reflect.Type]func(string) (interface{}, error)) *Configurator {
+	for t, fn := range parsers {
+		c.RegisterParser(t, fn)
+	}
+	return c
+}
+
+// WithAutoEnv makes readStructMetadata synthesize an env var name from
+// a field's struct path (see AutoEnvStyle) whenever the field carries
+// no explicit env tag, removing the need to tag every field by hand.
+// Add env:"-" to a field to opt it out of auto-derivation.
+func (c *Configurator) WithAutoEnv(style AutoEnvStyle) *Configurator {
+	c.autoEnvStyle = style
+	return c
+}
+
+// WithEnvFilePrefix sets a base directory prepended to relative
+// env-file tag paths, e.g. "/run/secrets" for Docker/Kubernetes secret
+// mounts.
+func (c *Configurator) WithEnvFilePrefix(prefix string) *Configurator {
+	c.envFilePrefix = prefix
+	return c
+}
+
+// WithInterpolationVars sets additional variables used to resolve
+// ${NAME} references found in loaded config files. These take
+// precedence over the process environment.
+func (c *Configurator) WithInterpolationVars(vars map[string]string) *Configurator {
+	c.interpolationVars = vars
+	return c
+}
+
+// WithStrict enables strict interpolation: a ${NAME} reference with
+// no default and no matching variable returns an error instead of
+// being left untouched in the parsed output.
+func (c *Configurator) WithStrict(strict bool) *Configurator {
+	c.strict = strict
+	return c
+}
+
 // Parser returns the internal parser instance.
 func (c *Configurator) Parser() Parser {
 	return c.parser
@@ -143,15 +237,25 @@ func (c *Configurator) FileName() string {
 	return c.fileNamePrefix + "." + c.fileType
 }
 
-// Load reads configuration from default file into the cfg structure.
+// Load reads configuration into the cfg structure. Registered Source(s)
+// (see WithSource) are tried first, in registration order, falling
+// back to the default file search paths if none of them succeed.
 func (c *Configurator) Load(cfg interface{}) error {
 	var err error
-	for _, p := range c.paths {
-		err = c.LoadFromFile(p+"/"+c.FileName(), cfg)
+
+	for _, source := range c.sources {
+		err = c.loadFromSource(source, cfg)
 		if err == nil {
 			return nil
 		}
 	}
+
+	if ferr := c.loadFromPaths(cfg); ferr == nil {
+		return nil
+	} else if err == nil {
+		err = ferr
+	}
+
 	return err
 }
 
@@ -163,7 +267,17 @@ func (c *Configurator) LoadFromFile(path string, cfg interface{}) error {
 	}
 	defer f.Close()
 
-	err = c.Parser().Parse(f, cfg)
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+
+	raw, err = c.interpolate(raw)
+	if err != nil {
+		return err
+	}
+
+	err = c.Parser().Parse(bytes.NewReader(raw), cfg)
 	if err != nil {
 		if e, ok := err.(*os.PathError); ok {
 			return e
@@ -171,15 +285,30 @@ func (c *Configurator) LoadFromFile(path string, cfg interface{}) error {
 		return fmt.Errorf("config file parsing error: %s", err.Error())
 	}
 
-	return c.readEnvVars(cfg)
+	if err := c.readEnvVars(cfg); err != nil {
+		return err
+	}
+
+	c.loadedPath = path
+	c.loadedViaSource = false
+	return nil
+}
+
+// structStackEntry is a pending struct to process in readStructMetadata,
+// together with the struct path built up so far (used to synthesize
+// env names when WithAutoEnv is set).
+type structStackEntry struct {
+	value interface{}
+	path  []string
 }
 
 func (c *Configurator) readStructMetadata(cfgRoot interface{}) ([]metadata, error) {
-	cfgStack := []interface{}{cfgRoot}
+	cfgStack := []structStackEntry{{value: cfgRoot}}
 	metas := make([]metadata, 0)
 
 	for i := 0; i < len(cfgStack); i++ {
-		s := reflect.ValueOf(cfgStack[i])
+		entry := cfgStack[i]
+		s := reflect.ValueOf(entry.value)
 
 		// unwrap pointer
 		if s.Kind() == reflect.Ptr {
@@ -201,17 +330,29 @@ func (c *Configurator) readStructMetadata(cfgRoot interface{}) ([]metadata, erro
 				separator string
 			)
 
-			// process nested structure (except of time.Time)
+			// process nested structure (except of time.Time and any
+			// struct type bound to a custom parser or Setter, which
+			// are leaf fields, not structures to recurse into)
 			if fld := s.Field(idx); fld.Kind() == reflect.Struct {
-				// add structure to parsing stack
-				if fld.Type() != reflect.TypeOf(time.Time{}) {
-					cfgStack = append(cfgStack, fld.Addr().Interface())
+				_, hasCustomParser := c.customParsers[fld.Type()]
+				_, isSetter := fld.Addr().Interface().(Setter)
+
+				if fld.Type() == reflect.TypeOf(time.Time{}) {
+					// process time.Time
+					if l, ok := fType.Tag.Lookup(TagEnvLayout); ok {
+						layout = &l
+					}
+				} else if !hasCustomParser && !isSetter {
+					// add structure to parsing stack
+					childPath := entry.path
+					if prefix, ok := fType.Tag.Lookup(TagEnvPrefix); ok {
+						childPath = []string{prefix}
+					} else {
+						childPath = append(append([]string{}, childPath...), fType.Name)
+					}
+					cfgStack = append(cfgStack, structStackEntry{value: fld.Addr().Interface(), path: childPath})
 					continue
 				}
-				// process time.Time
-				if l, ok := fType.Tag.Lookup(TagEnvLayout); ok {
-					layout = &l
-				}
 			}
 
 			// check if the field value can be changed
@@ -233,7 +374,16 @@ func (c *Configurator) readStructMetadata(cfgRoot interface{}) ([]metadata, erro
 			envList := make([]string, 0)
 
 			if envs, ok := fType.Tag.Lookup(TagEnv); ok && len(envs) != 0 {
-				envList = strings.Split(envs, DefaultSeparator)
+				if envs != "-" {
+					envList = strings.Split(envs, DefaultSeparator)
+				}
+			} else if c.autoEnvStyle != 0 {
+				envList = []string{autoEnvName(append(append([]string{}, entry.path...), fType.Name), c.autoEnvStyle)}
+			}
+
+			var envFile *string
+			if ef, ok := fType.Tag.Lookup(TagEnvFile); ok {
+				envFile = &ef
 			}
 
 			metas = append(metas, metadata{
@@ -245,6 +395,7 @@ func (c *Configurator) readStructMetadata(cfgRoot interface{}) ([]metadata, erro
 				separator:   separator,
 				description: fType.Tag.Get(TagEnvDescription),
 				required:    required,
+				envFile:     envFile,
 			})
 		}
 	}
@@ -260,7 +411,10 @@ func (c *Configurator) readEnvVars(cfg interface{}) error {
 	}
 
 	for _, meta := range metaInfo {
-		var rawValue *string
+		var (
+			rawValue    *string
+			fromEnvFile bool
+		)
 
 		for _, env := range meta.env {
 			if value, ok := os.LookupEnv(c.envPrefix + env); ok {
@@ -269,6 +423,29 @@ func (c *Configurator) readEnvVars(cfg interface{}) error {
 			}
 		}
 
+		if rawValue == nil {
+			for _, env := range meta.env {
+				if path, ok := os.LookupEnv(c.envPrefix + env + "_FILE"); ok {
+					value, err := c.readEnvFile(path)
+					if err != nil {
+						return err
+					}
+					rawValue = &value
+					fromEnvFile = true
+					break
+				}
+			}
+		}
+
+		if rawValue == nil && meta.envFile != nil {
+			value, err := c.readEnvFile(c.resolveEnvFilePath(*meta.envFile))
+			if err != nil {
+				return err
+			}
+			rawValue = &value
+			fromEnvFile = true
+		}
+
 		if rawValue == nil && meta.required && meta.isFieldValueZero() {
 			err := fmt.Errorf("field %q is required but the value is not provided",
 				meta.fieldName)
@@ -284,6 +461,9 @@ func (c *Configurator) readEnvVars(cfg interface{}) error {
 		}
 
 		if err := c.parseValue(meta.fieldValue, *rawValue, meta.separator, meta.layout); err != nil {
+			if fromEnvFile {
+				return fmt.Errorf("env-file: could not parse value for field %q: %w", meta.fieldName, err)
+			}
 			return err
 		}
 	}
@@ -306,6 +486,15 @@ func (c *Configurator) parseValue(field reflect.Value, value, sep string, layout
 
 	valueType := field.Type()
 
+	if fn, ok := c.customParsers[valueType]; ok {
+		parsed, err := fn(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+
 	switch valueType.Kind() {
 	// parse string value
 	case reflect.String:
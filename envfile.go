@@ -0,0 +1,42 @@
+// Copyright 2020 Luca Stasio. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// resolveEnvFilePath prepends envFilePrefix to a relative env-file tag
+// path, e.g. WithEnvFilePrefix("/run/secrets") turns "db-password"
+// into "/run/secrets/db-password".
+func (c *Configurator) resolveEnvFilePath(path string) string {
+	if c.envFilePrefix == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(c.envFilePrefix, path)
+}
+
+// readEnvFile reads and trims the contents of path, used both by the
+// env-file tag and the "_FILE" suffix (Docker/Kubernetes secret)
+// convention. Errors are wrapped so the caller can tell whether the
+// failure happened on open or on read.
+func (c *Configurator) readEnvFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("env-file: could not open %q: %w", path, err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", fmt.Errorf("env-file: could not read %q: %w", path, err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnvFileTag(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "db-password")
+	if err := os.WriteFile(path, []byte("s3cr3t\n"), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	type Cfg struct {
+		DBPassword string `env-file:"db-password"`
+	}
+
+	c := NewConfigurator().WithEnvFilePrefix(dir)
+	cfg := Cfg{}
+	if err := c.readEnvVars(&cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if cfg.DBPassword != "s3cr3t" {
+		t.Fatalf("cfg.DBPassword expected 's3cr3t' is %q", cfg.DBPassword)
+	}
+}
+
+func TestEnvFileSuffixConvention(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	if err := os.WriteFile(path, []byte("abc123\n"), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	type Cfg struct {
+		Token string `env:"TOKEN"`
+	}
+
+	os.Setenv("TOKEN_FILE", path)
+	defer os.Unsetenv("TOKEN_FILE")
+
+	c := NewConfigurator()
+	cfg := Cfg{}
+	if err := c.readEnvVars(&cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if cfg.Token != "abc123" {
+		t.Fatalf("cfg.Token expected 'abc123' is %q", cfg.Token)
+	}
+}
+
+func TestEnvFileParseErrorIsWrapped(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "port")
+	if err := os.WriteFile(path, []byte("not-a-number\n"), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	type Cfg struct {
+		Port int `env-file:"port"`
+	}
+
+	c := NewConfigurator().WithEnvFilePrefix(dir)
+	cfg := Cfg{}
+	err := c.readEnvVars(&cfg)
+	if err == nil {
+		t.Fatalf("expected a parse error, got nil")
+	}
+
+	if !strings.HasPrefix(err.Error(), "env-file: could not parse value for field") {
+		t.Fatalf("expected error to be wrapped with env-file context, got %q", err.Error())
+	}
+}
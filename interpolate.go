@@ -0,0 +1,140 @@
+// Copyright 2020 Luca Stasio. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// interpolate expands ${NAME}, ${NAME:-default}, ${NAME-default},
+// ${NAME:?err} and ${NAME?err} shell-style references found in raw,
+// a config file's raw bytes, before it is handed to the Parser.
+// A literal "$" is produced by escaping it as "$$".
+func (c *Configurator) interpolate(raw []byte) ([]byte, error) {
+	var out strings.Builder
+	input := string(raw)
+
+	for i := 0; i < len(input); i++ {
+		ch := input[i]
+		if ch != '$' {
+			out.WriteByte(ch)
+			continue
+		}
+
+		// "$$" is an escaped literal "$"
+		if i+1 < len(input) && input[i+1] == '$' {
+			out.WriteByte('$')
+			i++
+			continue
+		}
+
+		if i+1 >= len(input) || input[i+1] != '{' {
+			out.WriteByte(ch)
+			continue
+		}
+
+		end := strings.IndexByte(input[i+2:], '}')
+		if end == -1 {
+			out.WriteByte(ch)
+			continue
+		}
+		end += i + 2
+
+		value, err := c.resolveReference(input[i+2 : end])
+		if err != nil {
+			return nil, err
+		}
+		out.WriteString(value)
+		i = end
+	}
+
+	return []byte(out.String()), nil
+}
+
+// resolveReference resolves the inner content of a "${...}" reference,
+// e.g. "NAME", "NAME:-default", "NAME-default", "NAME:?err" or "NAME?err".
+func (c *Configurator) resolveReference(ref string) (string, error) {
+	name, op, arg := splitReference(ref)
+	value, ok := c.lookupInterpolationVar(name)
+
+	switch op {
+	case ":-":
+		if !ok || value == "" {
+			return arg, nil
+		}
+		return value, nil
+	case "-":
+		if !ok {
+			return arg, nil
+		}
+		return value, nil
+	case ":?":
+		if !ok || value == "" {
+			return "", fmt.Errorf("config: required variable %q is not set: %s", name, arg)
+		}
+		return value, nil
+	case "?":
+		if !ok {
+			return "", fmt.Errorf("config: required variable %q is not set: %s", name, arg)
+		}
+		return value, nil
+	default:
+		if ok {
+			return value, nil
+		}
+		if c.strict {
+			return "", fmt.Errorf("config: variable %q is not set", name)
+		}
+		return "${" + ref + "}", nil
+	}
+}
+
+// splitReference splits a "${...}" body into its variable name,
+// operator (one of "", "-", ":-", "?", ":?") and operator argument.
+func splitReference(ref string) (name, op, arg string) {
+	for i := 0; i < len(ref); i++ {
+		r := ref[i]
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+
+		rest := ref[i:]
+		switch {
+		case strings.HasPrefix(rest, ":-"):
+			return ref[:i], ":-", rest[2:]
+		case strings.HasPrefix(rest, ":?"):
+			return ref[:i], ":?", rest[2:]
+		case strings.HasPrefix(rest, "-"):
+			return ref[:i], "-", rest[1:]
+		case strings.HasPrefix(rest, "?"):
+			return ref[:i], "?", rest[1:]
+		default:
+			return ref, "", ""
+		}
+	}
+
+	return ref, "", ""
+}
+
+// lookupInterpolationVar resolves a variable name used during file
+// interpolation. User-supplied vars (WithInterpolationVars) take
+// precedence over the process environment; when envPrefix is set,
+// unprefixed lookups also try the prefixed name.
+func (c *Configurator) lookupInterpolationVar(name string) (string, bool) {
+	if v, ok := c.interpolationVars[name]; ok {
+		return v, true
+	}
+	if v, ok := os.LookupEnv(name); ok {
+		return v, true
+	}
+	if c.envPrefix != "" {
+		if v, ok := os.LookupEnv(c.envPrefix + name); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	os.Setenv("INTERP_HOST", "example.com")
+	os.Unsetenv("INTERP_MISSING")
+
+	c := NewConfigurator().WithInterpolationVars(map[string]string{"NAME": "Frank"})
+
+	out, err := c.interpolate([]byte("host: ${INTERP_HOST}\nname: ${NAME}\nprice: $$5\nport: ${INTERP_MISSING:-8080}\n"))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expected := "host: example.com\nname: Frank\nprice: $5\nport: 8080\n"
+	if string(out) != expected {
+		t.Fatalf("interpolate expected %q is %q", expected, string(out))
+	}
+}
+
+func TestInterpolateRequiredMissing(t *testing.T) {
+	os.Unsetenv("INTERP_REQUIRED")
+
+	c := NewConfigurator()
+	_, err := c.interpolate([]byte("token: ${INTERP_REQUIRED:?token is required}"))
+	if err == nil {
+		t.Fatalf("expected an error for a missing required variable")
+	}
+}
+
+func TestInterpolateStrict(t *testing.T) {
+	os.Unsetenv("INTERP_UNDEFINED")
+
+	c := NewConfigurator().WithStrict(true)
+	_, err := c.interpolate([]byte("value: ${INTERP_UNDEFINED}"))
+	if err == nil {
+		t.Fatalf("expected an error in strict mode for an undefined variable")
+	}
+}
@@ -17,6 +17,7 @@ type metadata struct {
 	separator   string
 	description string
 	required    bool
+	envFile     *string
 }
 
 // isFieldValueZero determines if fieldValue empty or not
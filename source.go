@@ -0,0 +1,163 @@
+// Copyright 2020 Luca Stasio. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Source abstracts a place configuration can be read from besides the
+// local filesystem, e.g. a key/value store or an HTTP endpoint. Read
+// returns the raw body together with a content type (such as
+// "application/yaml" or "application/json") used to pick a Parser
+// when the source doesn't force one of its own.
+type Source interface {
+	Read(ctx context.Context) (body io.ReadCloser, contentType string, err error)
+}
+
+// formatOverrider is implemented by sources that can force a specific
+// parser format instead of relying on content-type sniffing.
+type formatOverrider interface {
+	formatOverride() string
+}
+
+// WithSource registers a remote Source to try before falling back to
+// the filesystem search paths. Sources are tried in registration order;
+// the first one that reads and parses successfully wins.
+func (c *Configurator) WithSource(source Source) *Configurator {
+	c.sources = append(c.sources, source)
+	return c
+}
+
+// loadFromPaths tries the filesystem search paths, the way Load always
+// has.
+func (c *Configurator) loadFromPaths(cfg interface{}) error {
+	var err error
+	for _, p := range c.paths {
+		err = c.LoadFromFile(p+"/"+c.FileName(), cfg)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// loadFromSource reads and parses a single Source into cfg.
+func (c *Configurator) loadFromSource(source Source, cfg interface{}) error {
+	body, contentType, err := source.Read(context.Background())
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	raw, err = c.interpolate(raw)
+	if err != nil {
+		return err
+	}
+
+	parser := c.sourceParser(source, contentType)
+	if err := parser.Parse(bytes.NewReader(raw), cfg); err != nil {
+		return fmt.Errorf("config file parsing error: %s", err.Error())
+	}
+
+	if err := c.readEnvVars(cfg); err != nil {
+		return err
+	}
+
+	c.loadedViaSource = true
+	return nil
+}
+
+// sourceParser picks the Parser to use for a Source's body: an
+// explicit per-source format override wins, then the content type
+// returned by the source, then the Configurator's own format.
+func (c *Configurator) sourceParser(source Source, contentType string) Parser {
+	if fo, ok := source.(formatOverrider); ok {
+		if format := fo.formatOverride(); format != "" {
+			return NewParser(format)
+		}
+	}
+
+	if format := formatFromContentType(contentType); format != "" {
+		return NewParser(format)
+	}
+
+	if c.parser != nil {
+		return c.parser
+	}
+
+	return NewParser(c.fileType)
+}
+
+// formatFromContentType maps a MIME content type to one of the format
+// strings accepted by NewParser, ignoring an empty result means "unknown".
+func formatFromContentType(contentType string) string {
+	ct := strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+
+	switch ct {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return "yaml"
+	case "application/json":
+		return "json"
+	case "application/toml", "text/toml":
+		return "toml"
+	default:
+		return ""
+	}
+}
+
+// HTTPSource fetches configuration from an HTTP(S) endpoint. The
+// parser is picked from the response's Content-Type header unless
+// Format is set.
+type HTTPSource struct {
+	URL    string
+	Header http.Header
+	Client *http.Client
+	Format string
+}
+
+func (s *HTTPSource) formatOverride() string {
+	return s.Format
+}
+
+// Read implements Source.
+func (s *HTTPSource) Read(ctx context.Context) (io.ReadCloser, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	for k, values := range s.Header {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("config: http source %q returned status %d", s.URL, resp.StatusCode)
+	}
+
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 Luca Stasio. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ConsulKVSource reads a configuration blob from a single key in
+// Consul's KV store, using Consul's HTTP API directly.
+type ConsulKVSource struct {
+	Address    string
+	Prefix     string
+	Token      string
+	Datacenter string
+	Format     string
+}
+
+func (s *ConsulKVSource) formatOverride() string {
+	return s.Format
+}
+
+// Read implements Source.
+func (s *ConsulKVSource) Read(ctx context.Context) (io.ReadCloser, string, error) {
+	u := fmt.Sprintf("%s/v1/kv/%s?raw", s.Address, url.PathEscape(s.Prefix))
+	if s.Datacenter != "" {
+		u += "&dc=" + url.QueryEscape(s.Datacenter)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if s.Token != "" {
+		req.Header.Set("X-Consul-Token", s.Token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("config: consul kv source %q returned status %d", s.Prefix, resp.StatusCode)
+	}
+
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
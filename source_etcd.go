@@ -0,0 +1,96 @@
+// Copyright 2020 Luca Stasio. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// EtcdSource reads a configuration blob from a single key in etcd,
+// using the v3 gRPC-gateway JSON API so the source needs no etcd
+// client dependency.
+type EtcdSource struct {
+	Endpoints []string
+	Key       string
+	Format    string
+}
+
+func (s *EtcdSource) formatOverride() string {
+	return s.Format
+}
+
+type etcdRangeResponse struct {
+	Kvs []struct {
+		Value string `json:"value"`
+	} `json:"kvs"`
+}
+
+// Read implements Source.
+func (s *EtcdSource) Read(ctx context.Context) (io.ReadCloser, string, error) {
+	if len(s.Endpoints) == 0 {
+		return nil, "", fmt.Errorf("config: etcd source has no endpoints")
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"key": base64.StdEncoding.EncodeToString([]byte(s.Key)),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var lastErr error
+	for _, endpoint := range s.Endpoints {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/v3/kv/range", bytes.NewReader(body))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		raw, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			lastErr = fmt.Errorf("config: etcd source %q returned status %d", s.Key, resp.StatusCode)
+			continue
+		}
+
+		var parsed etcdRangeResponse
+		if err := json.Unmarshal(raw, &parsed); err != nil {
+			lastErr = err
+			continue
+		}
+
+		if len(parsed.Kvs) == 0 {
+			lastErr = fmt.Errorf("config: etcd key %q not found", s.Key)
+			continue
+		}
+
+		value, err := base64.StdEncoding.DecodeString(parsed.Kvs[0].Value)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return io.NopCloser(bytes.NewReader(value)), "", nil
+	}
+
+	return nil, "", lastErr
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write([]byte("name: Frank\n"))
+	}))
+	defer srv.Close()
+
+	c := NewConfigurator().WithSource(&HTTPSource{URL: srv.URL})
+
+	type Cfg struct {
+		Name string `yaml:"name"`
+	}
+	cfg := &Cfg{}
+	if err := c.Load(cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if cfg.Name != "Frank" {
+		t.Fatalf("cfg.Name expected 'Frank' is %q", cfg.Name)
+	}
+}
+
+func TestConsulKVSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("dc"); got != "dc1" {
+			t.Errorf("expected dc=dc1 query param, got %q", got)
+		}
+		if r.URL.Path != "/v1/kv/myapp/config.yaml" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write([]byte("name: Frank\n"))
+	}))
+	defer srv.Close()
+
+	c := NewConfigurator().WithSource(&ConsulKVSource{
+		Address:    srv.URL,
+		Prefix:     "myapp/config.yaml",
+		Datacenter: "dc1",
+	})
+
+	type Cfg struct {
+		Name string `yaml:"name"`
+	}
+	cfg := &Cfg{}
+	if err := c.Load(cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if cfg.Name != "Frank" {
+		t.Fatalf("cfg.Name expected 'Frank' is %q", cfg.Name)
+	}
+}
+
+func TestEtcdSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/kv/range" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+
+		var req struct {
+			Key string `json:"key"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %s", err)
+		}
+		key, err := base64.StdEncoding.DecodeString(req.Key)
+		if err != nil {
+			t.Fatalf("decode key: %s", err)
+		}
+		if string(key) != "/myapp/config.yaml" {
+			t.Errorf("expected key %q, got %q", "/myapp/config.yaml", key)
+		}
+
+		resp := etcdRangeResponse{
+			Kvs: []struct {
+				Value string `json:"value"`
+			}{
+				{Value: base64.StdEncoding.EncodeToString([]byte("name: Frank\n"))},
+			},
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	c := NewConfigurator().WithSource(&EtcdSource{
+		Endpoints: []string{srv.URL},
+		Key:       "/myapp/config.yaml",
+		Format:    "yaml",
+	})
+
+	type Cfg struct {
+		Name string `yaml:"name"`
+	}
+	cfg := &Cfg{}
+	if err := c.Load(cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if cfg.Name != "Frank" {
+		t.Fatalf("cfg.Name expected 'Frank' is %q", cfg.Name)
+	}
+}
+
+func TestFormatFromContentType(t *testing.T) {
+	cases := map[string]string{
+		"application/yaml; charset=utf-8": "yaml",
+		"application/json":                "json",
+		"text/toml":                       "toml",
+		"text/plain":                      "",
+	}
+
+	for ct, expected := range cases {
+		if got := formatFromContentType(ct); got != expected {
+			t.Fatalf("formatFromContentType(%q) expected %q is %q", ct, expected, got)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2020 Luca Stasio. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces bursts of filesystem events (editors
+// commonly write+rename on every save) into a single reload.
+const watchDebounce = 200 * time.Millisecond
+
+// watchPollInterval is how often registered Source(s) are polled for
+// changes when no local file is being watched. Consul and etcd support
+// long-polling/blocking queries server-side, but plain periodic polling
+// keeps this path simple and provider-agnostic.
+const watchPollInterval = 5 * time.Second
+
+// Watch monitors the configuration for changes and keeps Snapshot()
+// up to date. If the last successful Load used a registered Source
+// (see WithSource), that takes precedence and the Source(s) are
+// polled on an interval; this is checked before anything else, since
+// the default file search paths can otherwise contain an unrelated
+// file (e.g. a committed example config) that Load correctly ignored
+// in favor of the Source. Otherwise, when Load's search paths resolve
+// to a file, that file (plus an adjacent ".env" file if one is
+// present) is watched via fsnotify. When no such file exists but
+// Source(s) are registered, they are polled on an interval instead.
+// cfg is used as the initial snapshot and to learn the concrete type
+// to reload into; on every change a fresh value is parsed and
+// atomically swapped in via Snapshot, rather than cfg's fields being
+// mutated in place, so callers that keep reading cfg directly never
+// observe a half-applied update. Callers that need the live value
+// must read it through Snapshot(), not through the cfg pointer they
+// passed in. Parse errors are reported through onChange without
+// touching the snapshot, so a broken update never replaces a working
+// configuration. The returned stop function releases the watcher; it
+// is also released when ctx is canceled.
+func (c *Configurator) Watch(ctx context.Context, cfg interface{}, onChange func(err error)) (func(), error) {
+	if c.loadedViaSource && len(c.sources) > 0 {
+		return c.watchSources(ctx, cfg, onChange), nil
+	}
+
+	path, err := c.resolveLoadedPath()
+	if err != nil {
+		if len(c.sources) > 0 {
+			return c.watchSources(ctx, cfg, onChange), nil
+		}
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	c.current.Store(cfg)
+
+	ctx, cancel := context.WithCancel(ctx)
+	envPath := filepath.Join(dir, ".env")
+	go c.watchLoop(ctx, watcher, dir, path, envPath, cfg, onChange)
+
+	// close the watcher once ctx is done, whether stop was called or
+	// the caller's ctx was canceled.
+	go func() {
+		<-ctx.Done()
+		watcher.Close()
+	}()
+
+	return cancel, nil
+}
+
+// Snapshot returns the most recently loaded configuration value
+// registered through Watch. It is the only race-free way to observe
+// updates: the value it returns is never mutated after being stored,
+// each reload instead storing a brand new one.
+func (c *Configurator) Snapshot() interface{} {
+	return c.current.Load()
+}
+
+// watchSources polls registered Source(s) on watchPollInterval and
+// atomically swaps in the loaded value whenever it changes.
+func (c *Configurator) watchSources(ctx context.Context, cfg interface{}, onChange func(err error)) func() {
+	c.current.Store(cfg)
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		ticker := time.NewTicker(watchPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-ticker.C:
+				fresh := reflect.New(reflect.TypeOf(cfg).Elem()).Interface()
+
+				var err error
+				loaded := false
+				for _, source := range c.sources {
+					if err = c.loadFromSource(source, fresh); err == nil {
+						loaded = true
+						break
+					}
+				}
+
+				if !loaded {
+					onChange(err)
+					continue
+				}
+
+				if reflect.DeepEqual(fresh, c.current.Load()) {
+					continue
+				}
+
+				c.current.Store(fresh)
+				onChange(nil)
+			}
+		}
+	}()
+
+	return cancel
+}
+
+func (c *Configurator) watchLoop(ctx context.Context, watcher *fsnotify.Watcher, dir, path, envPath string, cfg interface{}, onChange func(err error)) {
+	var timer *time.Timer
+	var envChanged bool
+
+	reload := func() {
+		if envChanged {
+			envChanged = false
+			if err := c.reloadEnvFile(envPath); err != nil {
+				onChange(err)
+				return
+			}
+		}
+
+		fresh := reflect.New(reflect.TypeOf(cfg).Elem()).Interface()
+		if err := c.LoadFromFile(path, fresh); err != nil {
+			onChange(err)
+			return
+		}
+
+		c.current.Store(fresh)
+		onChange(nil)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			// editors often remove/rename then recreate the file on
+			// save; re-add the parent directory watch so we keep
+			// seeing events for the new inode.
+			if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				_ = watcher.Add(dir)
+			}
+
+			name := filepath.Clean(event.Name)
+			switch name {
+			case filepath.Clean(envPath):
+				envChanged = true
+			case filepath.Clean(path):
+				// main config file changed; envChanged, if already
+				// set by an earlier event in this debounce window, is
+				// left alone so both get re-applied on reload.
+			default:
+				continue
+			}
+
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(watchDebounce, reload)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			onChange(err)
+		}
+	}
+}
+
+// reloadEnvFile reads an adjacent ".env" file and applies its
+// variables to the process environment via ENVParser, the parser
+// LoadFromFile uses for "env"-format files; LoadFromFile itself never
+// reads this adjacent file. Without this, editing ".env" would
+// still trigger a reload but readEnvVars would just see the same,
+// stale environment. It is a no-op if the file doesn't exist, since
+// the ".env" file watched by Watch is always optional.
+func (c *Configurator) reloadEnvFile(envPath string) error {
+	f, err := os.Open(envPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	return (&ENVParser{}).Parse(f, nil)
+}
+
+// resolveLoadedPath returns the file LoadFromFile last parsed
+// successfully (tracked on the Configurator as loadedPath). Falling
+// back to re-scanning the search paths with os.Stat would only check
+// that a file exists there, not that it's the one Load actually used
+// (an earlier search path can have a file that exists but fails to
+// parse, while Load falls through to a later, valid one) so that path
+// is only used when no Load has happened yet.
+func (c *Configurator) resolveLoadedPath() (string, error) {
+	if c.loadedPath != "" {
+		return c.loadedPath, nil
+	}
+
+	for _, p := range c.paths {
+		path := p + "/" + c.FileName()
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("config: no loadable file found for %q in search paths", c.FileName())
+}
@@ -0,0 +1,273 @@
+package config
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type watchCfg struct {
+	Name string `yaml:"name"`
+}
+
+func TestWatchUsesThePathLoadActuallyUsed(t *testing.T) {
+	badDir := t.TempDir()
+	goodDir := t.TempDir()
+
+	c := NewConfiguratorFor("yaml").WithPath(badDir).WithPath(goodDir)
+
+	// an earlier search path whose file exists but fails to parse
+	badPath := filepath.Join(badDir, c.FileName())
+	if err := os.WriteFile(badPath, []byte("not: [valid"), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	goodPath := filepath.Join(goodDir, c.FileName())
+	if err := os.WriteFile(goodPath, []byte("name: Frank\n"), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	cfg := &watchCfg{}
+	if err := c.Load(cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	path, err := c.resolveLoadedPath()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if path != goodPath {
+		t.Fatalf("resolveLoadedPath() expected %q (the file Load actually parsed) is %q", goodPath, path)
+	}
+}
+
+func TestWatchFileReload(t *testing.T) {
+	dir := t.TempDir()
+	c := NewConfiguratorFor("yaml").WithPath(dir)
+
+	path := filepath.Join(dir, c.FileName())
+	if err := os.WriteFile(path, []byte("name: Frank\n"), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	cfg := &watchCfg{}
+	if err := c.Load(cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	changed := make(chan error, 1)
+	stop, err := c.Watch(context.Background(), cfg, func(err error) {
+		changed <- err
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer stop()
+
+	if err := os.WriteFile(path, []byte("name: Zappa\n"), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	select {
+	case err := <-changed:
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for reload")
+	}
+
+	// the original pointer is never mutated in place: callers must
+	// read the reloaded value through Snapshot().
+	if cfg.Name != "Frank" {
+		t.Fatalf("cfg.Name should stay untouched by Watch, is %q", cfg.Name)
+	}
+
+	got, ok := c.Snapshot().(*watchCfg)
+	if !ok {
+		t.Fatalf("Snapshot() returned %T, expected *watchCfg", c.Snapshot())
+	}
+	if got.Name != "Zappa" {
+		t.Fatalf("Snapshot().Name expected 'Zappa' is %q", got.Name)
+	}
+}
+
+func TestWatchEnvFileReload(t *testing.T) {
+	dir := t.TempDir()
+	c := NewConfiguratorFor("yaml").WithPath(dir)
+
+	path := filepath.Join(dir, c.FileName())
+	if err := os.WriteFile(path, []byte("name: Frank\n"), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	envPath := filepath.Join(dir, ".env")
+	if err := os.WriteFile(envPath, []byte("WATCH_SECRET=initial\n"), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err := c.reloadEnvFile(envPath); err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer os.Unsetenv("WATCH_SECRET")
+
+	type watchEnvCfg struct {
+		Name   string `yaml:"name"`
+		Secret string `env:"WATCH_SECRET"`
+	}
+
+	cfg := &watchEnvCfg{}
+	if err := c.Load(cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if cfg.Secret != "initial" {
+		t.Fatalf("cfg.Secret expected 'initial' is %q", cfg.Secret)
+	}
+
+	changed := make(chan error, 1)
+	stop, err := c.Watch(context.Background(), cfg, func(err error) {
+		changed <- err
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer stop()
+
+	if err := os.WriteFile(envPath, []byte("WATCH_SECRET=updated\n"), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	select {
+	case err := <-changed:
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for reload")
+	}
+
+	got, ok := c.Snapshot().(*watchEnvCfg)
+	if !ok {
+		t.Fatalf("Snapshot() returned %T, expected *watchEnvCfg", c.Snapshot())
+	}
+	if got.Secret != "updated" {
+		t.Fatalf("Snapshot().Secret expected 'updated' (re-read from the rewritten .env) is %q", got.Secret)
+	}
+}
+
+// pollingSource is a Source whose body can be swapped at runtime, used
+// to exercise Watch's poll-based reload path for registered sources.
+type pollingSource struct {
+	mu   sync.Mutex
+	body string
+}
+
+func (s *pollingSource) set(body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.body = body
+}
+
+func (s *pollingSource) Read(ctx context.Context) (io.ReadCloser, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return io.NopCloser(strings.NewReader(s.body)), "application/yaml", nil
+}
+
+func TestWatchSourcesPoll(t *testing.T) {
+	src := &pollingSource{body: "name: Frank\n"}
+
+	c := NewConfigurator().WithSource(src)
+
+	cfg := &watchCfg{}
+	if err := c.Load(cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	changed := make(chan error, 1)
+	stop, err := c.Watch(context.Background(), cfg, func(err error) {
+		changed <- err
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer stop()
+
+	src.set("name: Zappa\n")
+
+	select {
+	case err := <-changed:
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	case <-time.After(watchPollInterval + 2*time.Second):
+		t.Fatalf("timed out waiting for poll reload")
+	}
+
+	if cfg.Name != "Frank" {
+		t.Fatalf("cfg.Name should stay untouched by Watch, is %q", cfg.Name)
+	}
+
+	got, ok := c.Snapshot().(*watchCfg)
+	if !ok {
+		t.Fatalf("Snapshot() returned %T, expected *watchCfg", c.Snapshot())
+	}
+	if got.Name != "Zappa" {
+		t.Fatalf("Snapshot().Name expected 'Zappa' is %q", got.Name)
+	}
+}
+
+func TestWatchPrefersSourceOverStaleSearchPathFile(t *testing.T) {
+	dir := t.TempDir()
+	c := NewConfiguratorFor("yaml").WithPath(dir)
+
+	// a file on the default search path that Load ignores in favor of
+	// the registered Source below, e.g. a committed example config.
+	stalePath := filepath.Join(dir, c.FileName())
+	if err := os.WriteFile(stalePath, []byte("name: Stale\n"), 0o600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	src := &pollingSource{body: "name: Frank\n"}
+	c.WithSource(src)
+
+	cfg := &watchCfg{}
+	if err := c.Load(cfg); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if cfg.Name != "Frank" {
+		t.Fatalf("cfg.Name expected 'Frank' (from the Source) is %q", cfg.Name)
+	}
+
+	changed := make(chan error, 1)
+	stop, err := c.Watch(context.Background(), cfg, func(err error) {
+		changed <- err
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer stop()
+
+	src.set("name: Zappa\n")
+
+	select {
+	case err := <-changed:
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	case <-time.After(watchPollInterval + 2*time.Second):
+		t.Fatalf("timed out waiting for poll reload; Watch likely fell back to watching the stale search-path file instead of polling the Source")
+	}
+
+	got, ok := c.Snapshot().(*watchCfg)
+	if !ok {
+		t.Fatalf("Snapshot() returned %T, expected *watchCfg", c.Snapshot())
+	}
+	if got.Name != "Zappa" {
+		t.Fatalf("Snapshot().Name expected 'Zappa' is %q", got.Name)
+	}
+}